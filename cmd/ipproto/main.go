@@ -0,0 +1,78 @@
+// Command ipproto looks up IP protocol numbers and keywords, similar to
+// `getent protocols`.
+//
+// Usage:
+//
+//	ipproto 6        # -> "tcp 6 TCP"
+//	ipproto tcp       # -> "tcp 6 TCP"
+//	ipproto -json tcp # -> the full ipproto.Entry as JSON
+//	ipproto -load protocol-numbers.csv tcp
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/brnuts/ipproto"
+)
+
+func main() {
+	jsonOut := flag.Bool("json", false, "print the full entry as JSON")
+	loadPath := flag.String("load", "", "load protocol data from this CSV file instead of the embedded table")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: ipproto [-json] [-load path.csv] <number|name>")
+		os.Exit(2)
+	}
+
+	if *loadPath != "" {
+		if err := ipproto.LoadFromFile(*loadPath); err != nil {
+			fmt.Fprintln(os.Stderr, "ipproto:", err)
+			os.Exit(1)
+		}
+	}
+
+	entry, err := lookup(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ipproto:", err)
+		os.Exit(1)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entry); err != nil {
+			fmt.Fprintln(os.Stderr, "ipproto:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("%s %d %s\n", strings.ToLower(entry.Keyword), entry.DecimalStart, entry.Keyword)
+}
+
+// lookup resolves arg as either a protocol number or a name.
+func lookup(arg string) (*ipproto.Entry, error) {
+	if n, err := strconv.Atoi(arg); err == nil {
+		e, ok := ipproto.LookupByNumber(n)
+		if !ok {
+			return nil, fmt.Errorf("unknown protocol number: %d", n)
+		}
+		return e, nil
+	}
+
+	n, err := ipproto.Protocol(arg)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := ipproto.LookupByNumber(n)
+	if !ok {
+		return nil, fmt.Errorf("unknown protocol: %s", arg)
+	}
+	return e, nil
+}
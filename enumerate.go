@@ -0,0 +1,83 @@
+package ipproto
+
+import "strings"
+
+// Entries returns a snapshot copy of every loaded Entry, in CSV order.
+// Ranges like "148-252" appear once, not once per number they cover.
+func Entries() []Entry {
+	if err := ensureLoaded(); err != nil {
+		return nil
+	}
+
+	t := current.Load()
+	if t == nil {
+		return nil
+	}
+
+	out := make([]Entry, len(t.entries))
+	copy(out, t.entries)
+	return out
+}
+
+// ForEach calls fn for every loaded Entry, in CSV order, stopping early if
+// fn returns false.
+func ForEach(fn func(Entry) bool) {
+	for _, e := range Entries() {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// ReverseEntries returns a snapshot copy of every loaded Entry in descending
+// (reverse CSV) order, the counterpart to Entries.
+func ReverseEntries() []Entry {
+	fwd := Entries()
+	out := make([]Entry, len(fwd))
+	for i, e := range fwd {
+		out[len(fwd)-1-i] = e
+	}
+	return out
+}
+
+// ForEachReverse is ForEach but walks entries in descending (reverse CSV)
+// order, stopping early if fn returns false.
+func ForEachReverse(fn func(Entry) bool) {
+	for _, e := range ReverseEntries() {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// LookupRange returns every Entry whose range overlaps [start, end]
+// (inclusive), each appearing once even if its range spans multiple
+// numbers within [start, end].
+func LookupRange(start, end int) []Entry {
+	if err := ensureLoaded(); err != nil {
+		return nil
+	}
+
+	t := current.Load()
+	if t == nil {
+		return nil
+	}
+
+	var out []Entry
+	for _, e := range t.entries {
+		if e.DecimalStart <= end && e.DecimalEnd >= start {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// IsIPv6ExtensionHeader reports whether protocol number n is marked as an
+// IPv6 Extension Header, i.e. its "IPv6 Extension Header" CSV column is "Y".
+func IsIPv6ExtensionHeader(n int) bool {
+	e, ok := LookupByNumber(n)
+	if !ok {
+		return false
+	}
+	return strings.EqualFold(e.IPv6ExtHdr, "Y")
+}
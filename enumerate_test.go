@@ -0,0 +1,108 @@
+package ipproto
+
+import (
+	"strings"
+	"testing"
+)
+
+const enumerateTestCSV = `Decimal,Keyword,Protocol,IPv6 Extension Header,Reference
+6,TCP,Transmission Control,,
+17,UDP,User Datagram,,
+44,IPv6-Frag,Fragment Header for IPv6,Y,
+148-252,Unassigned,Unassigned,,
+`
+
+func TestEntriesAndForEach(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(enumerateTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	entries := Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Entries() returned %d entries, want 4 (ranges not expanded)", len(entries))
+	}
+	if entries[0].Keyword != "TCP" || entries[3].DecimalStart != 148 {
+		t.Fatalf("Entries() = %+v, want CSV order starting with TCP and ending with the 148-252 range", entries)
+	}
+
+	var walked []string
+	ForEach(func(e Entry) bool {
+		walked = append(walked, e.Keyword)
+		return true
+	})
+	if len(walked) != 4 || walked[0] != "TCP" {
+		t.Fatalf("ForEach walked %v, want CSV order", walked)
+	}
+
+	var stopped []string
+	ForEach(func(e Entry) bool {
+		stopped = append(stopped, e.Keyword)
+		return e.Keyword != "TCP"
+	})
+	if len(stopped) != 1 {
+		t.Fatalf("ForEach did not stop early: walked %v", stopped)
+	}
+}
+
+func TestReverseEntriesAndForEachReverse(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(enumerateTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	fwd := Entries()
+	rev := ReverseEntries()
+	if len(rev) != len(fwd) {
+		t.Fatalf("ReverseEntries() returned %d entries, want %d", len(rev), len(fwd))
+	}
+	for i, e := range rev {
+		if e.Keyword != fwd[len(fwd)-1-i].Keyword {
+			t.Fatalf("ReverseEntries() = %+v, want Entries() reversed (%+v)", rev, fwd)
+		}
+	}
+
+	var walked []string
+	ForEachReverse(func(e Entry) bool {
+		walked = append(walked, e.Keyword)
+		return true
+	})
+	if walked[0] != rev[0].Keyword {
+		t.Fatalf("ForEachReverse walked %v, want descending order", walked)
+	}
+}
+
+func TestLookupRange(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(enumerateTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	got := LookupRange(150, 200)
+	if len(got) != 1 || got[0].DecimalStart != 148 {
+		t.Fatalf("LookupRange(150, 200) = %+v, want a single entry spanning 148-252", got)
+	}
+
+	got = LookupRange(0, 5)
+	if len(got) != 0 {
+		t.Fatalf("LookupRange(0, 5) = %+v, want none", got)
+	}
+
+	got = LookupRange(6, 17)
+	if len(got) != 2 {
+		t.Fatalf("LookupRange(6, 17) = %+v, want TCP and UDP", got)
+	}
+}
+
+func TestIsIPv6ExtensionHeader(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(enumerateTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	if !IsIPv6ExtensionHeader(44) {
+		t.Error("IsIPv6ExtensionHeader(44) = false, want true")
+	}
+	if IsIPv6ExtensionHeader(6) {
+		t.Error("IsIPv6ExtensionHeader(6) = true, want false")
+	}
+	if IsIPv6ExtensionHeader(9999) {
+		t.Error("IsIPv6ExtensionHeader(9999) = true, want false")
+	}
+}
@@ -0,0 +1,113 @@
+package ipproto
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadFromEtcProtocols parses a Unix /etc/protocols file (see protocols(5))
+// and overrides the embedded data. Unlike the IANA CSV, /etc/protocols has
+// no long protocol name or IPv6 extension header column; only DecimalStart,
+// DecimalEnd and Keyword are populated for each Entry.
+func LoadFromEtcProtocols(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ipproto: open /etc/protocols: %w", err)
+	}
+	defer f.Close()
+	return LoadFromEtcProtocolsReader(f)
+}
+
+// LoadFromEtcProtocolsReader parses protocol data from the Unix
+// /etc/protocols file format and overrides the embedded data. Lines look
+// like:
+//
+//	tcp    6   TCP    # Transmission Control
+//
+// where the first field is the canonical keyword, the second is the
+// decimal number, and any remaining fields are aliases; everything from a
+// "#" to the end of the line is a comment. Aliases are registered in
+// byKeyword alongside the canonical keyword, so any of them can be used
+// with LookupDecimal.
+func LoadFromEtcProtocolsReader(r io.Reader) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	t, err := buildTableFromEtcProtocols(r)
+	if err != nil {
+		return err
+	}
+	current.Store(t)
+	return nil
+}
+
+// buildTableFromEtcProtocols parses the /etc/protocols format into a fresh
+// table, without touching any package-level state.
+func buildTableFromEtcProtocols(r io.Reader) (*table, error) {
+	scanner := bufio.NewScanner(r)
+
+	t := &table{
+		byNumber:       make(map[int]*Entry),
+		byKeyword:      make(map[string]*Entry),
+		byProtocolName: make(map[string]*Entry),
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		keyword := fields[0]
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		aliases := fields[2:]
+
+		e := Entry{
+			DecimalStart: n,
+			DecimalEnd:   n,
+			Keyword:      keyword,
+		}
+
+		t.entries = append(t.entries, e)
+		entryPtr := &t.entries[len(t.entries)-1]
+
+		if _, exists := t.byNumber[n]; !exists {
+			t.byNumber[n] = entryPtr
+		}
+
+		k := strings.ToUpper(keyword)
+		if _, exists := t.byKeyword[k]; !exists {
+			t.byKeyword[k] = entryPtr
+		}
+
+		for _, alias := range aliases {
+			a := strings.ToUpper(alias)
+			if _, exists := t.byKeyword[a]; !exists {
+				t.byKeyword[a] = entryPtr
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ipproto: read /etc/protocols: %w", err)
+	}
+	if len(t.entries) == 0 {
+		return nil, fmt.Errorf("ipproto: /etc/protocols is empty")
+	}
+
+	t.byAlias = buildAliasIndex(t)
+
+	return t, nil
+}
@@ -0,0 +1,42 @@
+package ipproto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFromEtcProtocolsReader(t *testing.T) {
+	const data = `# /etc/protocols
+ip      0   IP       # internet protocol, pseudo protocol number
+hopopt  0   HOPOPT   # IPv6 Hop-by-Hop Option
+tcp     6   TCP      # transmission control protocol
+udp     17  UDP
+`
+
+	if err := LoadFromEtcProtocolsReader(strings.NewReader(data)); err != nil {
+		t.Fatalf("LoadFromEtcProtocolsReader: %v", err)
+	}
+
+	// Real /etc/protocols files commonly list more than one keyword for the
+	// same decimal (e.g. "ip" then "hopopt" both at 0); getent protocols 0
+	// returns the first definition, so byNumber must keep it too.
+	e, ok := LookupByNumber(0)
+	if !ok || e.Keyword != "ip" {
+		t.Fatalf("LookupByNumber(0) = %+v, %v; want the first definition (ip)", e, ok)
+	}
+
+	n, ok := LookupDecimal("TCP")
+	if !ok || n != 6 {
+		t.Fatalf("LookupDecimal(%q) = %d, %v; want 6, true", "TCP", n, ok)
+	}
+
+	if _, ok := LookupByNumber(17); !ok {
+		t.Fatal("LookupByNumber(17) not found")
+	}
+}
+
+func TestLoadFromEtcProtocolsReaderEmpty(t *testing.T) {
+	if err := LoadFromEtcProtocolsReader(strings.NewReader("# nothing but comments\n")); err == nil {
+		t.Fatal("LoadFromEtcProtocolsReader with no entries: got nil error, want one")
+	}
+}
@@ -0,0 +1,185 @@
+package ipproto
+
+import (
+	"sort"
+	"strings"
+)
+
+// aliasSynonyms hardcodes well-known protocol name synonyms that IANA
+// doesn't record as an alternate Keyword or Protocol, so callers can use
+// either name interchangeably with LookupDecimalFuzzy.
+var aliasSynonyms = map[string][]string{
+	"ipip":      {"ipv4"},
+	"ipv4":      {"ipip"},
+	"icmpv6":    {"ipv6-icmp"},
+	"ipv6-icmp": {"icmpv6"},
+	"ah":        {"ipv6-auth"},
+	"ipv6-auth": {"ah"},
+	"ospf":      {"ospfigp"},
+	"ospfigp":   {"ospf"},
+}
+
+// foldName strips everything but letters and digits, lower-cases the rest,
+// and normalizes the "ipv6" / "ip6" spelling variants IANA names mix
+// between (e.g. "IPv6-Frag" vs "ip6-frag"), so "IPv6-Frag", "ip6-frag",
+// "ip6_frag" and "ipv6frag" all compare equal.
+func foldName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return strings.ReplaceAll(b.String(), "ipv6", "ip6")
+}
+
+// buildAliasIndex folds every Keyword and Protocol name in t, plus the
+// aliasSynonyms table, into a single case/punctuation-insensitive lookup.
+// It's computed once per table build (see buildTableFromCSV and
+// buildTableFromEtcProtocols) and stored as t.byAlias, so LookupDecimalFuzzy
+// is a lock-free map read rather than a rebuild on every call.
+func buildAliasIndex(t *table) map[string]*Entry {
+	idx := make(map[string]*Entry, len(t.byKeyword)+len(t.byProtocolName))
+
+	add := func(name string, e *Entry) {
+		k := foldName(name)
+		if k == "" {
+			return
+		}
+		if _, exists := idx[k]; !exists {
+			idx[k] = e
+		}
+	}
+
+	for kw, e := range t.byKeyword {
+		add(kw, e)
+	}
+	for _, e := range t.byProtocolName {
+		add(e.Protocol, e)
+	}
+
+	for name, synonyms := range aliasSynonyms {
+		target, ok := idx[foldName(name)]
+		if !ok {
+			continue
+		}
+		for _, syn := range synonyms {
+			add(syn, target)
+		}
+	}
+
+	return idx
+}
+
+// LookupDecimalFuzzy is LookupDecimal but additionally matches aliases
+// (e.g. "ipv6-frag", "ip6-frag", "ipv6frag", "IPV6-Frag", or well-known
+// synonyms like "ospf" vs "ospfigp") via a case- and punctuation-insensitive
+// index built from the loaded table.
+func LookupDecimalFuzzy(name string) (int, bool) {
+	if n, ok := LookupDecimal(name); ok {
+		return n, true
+	}
+
+	if err := ensureLoaded(); err != nil {
+		return 0, false
+	}
+
+	t := current.Load()
+	if t == nil {
+		return 0, false
+	}
+
+	e, ok := t.byAlias[foldName(name)]
+	if !ok {
+		return 0, false
+	}
+	return e.DecimalStart, true
+}
+
+// Suggest returns up to n Keyword values closest to name by Levenshtein
+// distance, ordered from closest to furthest (ties broken alphabetically).
+// It's meant for error messages like "unknown protocol %q, did you mean %v?".
+func Suggest(name string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	if err := ensureLoaded(); err != nil {
+		return nil
+	}
+
+	t := current.Load()
+	if t == nil {
+		return nil
+	}
+
+	target := foldName(name)
+
+	type scored struct {
+		keyword string
+		dist    int
+	}
+	seen := make(map[string]bool, len(t.byKeyword))
+	candidates := make([]scored, 0, len(t.byKeyword))
+	for _, e := range t.byKeyword {
+		if seen[e.Keyword] {
+			continue
+		}
+		seen[e.Keyword] = true
+		candidates = append(candidates, scored{e.Keyword, levenshtein(target, foldName(e.Keyword))})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].keyword < candidates[j].keyword
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	out := make([]string, len(candidates))
+	for i, c := range candidates {
+		out[i] = c.keyword
+	}
+	return out
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,47 @@
+package ipproto
+
+import (
+	"strings"
+	"testing"
+)
+
+const fuzzyTestCSV = `Decimal,Keyword,Protocol,IPv6 Extension Header,Reference
+6,TCP,Transmission Control,,
+44,IPv6-Frag,Fragment Header for IPv6,Y,
+89,OSPFIGP,OSPFIGP,,
+`
+
+func TestLookupDecimalFuzzy(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(fuzzyTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	for _, name := range []string{"ipv6-frag", "ip6-frag", "ipv6frag", "IPV6-Frag"} {
+		n, ok := LookupDecimalFuzzy(name)
+		if !ok || n != 44 {
+			t.Errorf("LookupDecimalFuzzy(%q) = %d, %v; want 44, true", name, n, ok)
+		}
+	}
+
+	// The request's own motivating example: ospf should resolve against the
+	// real keyword OSPFIGP.
+	n, ok := LookupDecimalFuzzy("ospf")
+	if !ok || n != 89 {
+		t.Errorf("LookupDecimalFuzzy(%q) = %d, %v; want 89, true", "ospf", n, ok)
+	}
+
+	if _, ok := LookupDecimalFuzzy("not-a-protocol"); ok {
+		t.Error("LookupDecimalFuzzy(\"not-a-protocol\") = _, true; want false")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	if err := LoadFromReader(strings.NewReader(fuzzyTestCSV)); err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+
+	got := Suggest("TPC", 1)
+	if len(got) != 1 || got[0] != "TCP" {
+		t.Fatalf("Suggest(%q, 1) = %v; want [TCP]", "TPC", got)
+	}
+}
@@ -0,0 +1,16 @@
+package ipproto
+
+import "fmt"
+
+// Protocol returns the protocol number for name, with the same signature
+// and semantics as the deprecated net.LookupProtocol: name is matched
+// case-insensitively against a protocol's Keyword or Protocol field. Unlike
+// net.LookupProtocol, which is stubbed out on several non-cgo builds,
+// Protocol works everywhere since it never touches the OS name resolver.
+func Protocol(name string) (int, error) {
+	n, ok := LookupDecimal(name)
+	if !ok {
+		return 0, fmt.Errorf("ipproto: unknown protocol: %q", name)
+	}
+	return n, nil
+}
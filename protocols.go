@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 //go:embed protocol-numbers.csv
@@ -29,27 +30,43 @@ type Entry struct {
 	Reference    string // "Reference" column, raw text
 }
 
-var (
-	mu             sync.RWMutex
+// table is an immutable snapshot of all loaded protocol data. Readers load
+// the current table through an atomic.Pointer, so lookups are never
+// blocked by a concurrent build; mu only guards the build-and-publish step
+// itself.
+type table struct {
 	entries        []Entry
 	byNumber       map[int]*Entry    // 6 -> TCP entry, 17 -> UDP, etc. (ranges expanded)
 	byKeyword      map[string]*Entry // "TCP" -> entry
 	byProtocolName map[string]*Entry // "transmission control" -> entry
+	byAlias        map[string]*Entry // folded Keyword/Protocol/synonym -> entry, see fuzzy.go
+}
 
-	loadOnce sync.Once
-	loadErr  error
+var (
+	mu      sync.Mutex // serializes builders only; current is read lock-free
+	current atomic.Pointer[table]
 )
 
-// ensureLoaded parses the embedded CSV once on first use.
+// ensureLoaded lazily parses the embedded CSV the first time it's needed.
+// It's a no-op once any table has been published, whether by ensureLoaded
+// itself or by an explicit LoadFromFile/LoadFromReader/LoadFromEtcProtocols/
+// RefreshFromIANA call, so it never clobbers a caller-installed table.
 func ensureLoaded() error {
-	loadOnce.Do(func() {
-		if len(embeddedCSV) == 0 {
-			loadErr = fmt.Errorf("ipproto: embedded CSV is empty; protocol-numbers.csv missing?")
-			return
-		}
-		loadErr = loadFromReader(bytes.NewReader(embeddedCSV))
-	})
-	return loadErr
+	if current.Load() != nil {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current.Load() != nil {
+		return nil
+	}
+
+	if len(embeddedCSV) == 0 {
+		return fmt.Errorf("ipproto: embedded CSV is empty; protocol-numbers.csv missing?")
+	}
+	return loadFromReaderLocked(bytes.NewReader(embeddedCSV))
 }
 
 // LoadFromFile parses the given CSV file and overrides the embedded data.
@@ -68,23 +85,23 @@ func LoadFromFile(path string) error {
 func LoadFromReader(r io.Reader) error {
 	mu.Lock()
 	defer mu.Unlock()
-
-	// Reset lazy loader and replace data.
-	loadOnce = sync.Once{}
-	loadErr = nil
-
 	return loadFromReaderLocked(r)
 }
 
-// loadFromReader is the actual parser (no locking).
-func loadFromReader(r io.Reader) error {
-	mu.Lock()
-	defer mu.Unlock()
-	return loadFromReaderLocked(r)
+// loadFromReaderLocked assumes mu is already held; it builds a new table
+// and atomically publishes it.
+func loadFromReaderLocked(r io.Reader) error {
+	t, err := buildTableFromCSV(r)
+	if err != nil {
+		return err
+	}
+	current.Store(t)
+	return nil
 }
 
-// loadFromReaderLocked assumes mu is already locked.
-func loadFromReaderLocked(r io.Reader) error {
+// buildTableFromCSV parses the IANA CSV format into a fresh table, without
+// touching any package-level state.
+func buildTableFromCSV(r io.Reader) (*table, error) {
 	cr := csv.NewReader(r)
 	cr.Comma = ','
 	cr.Comment = '#'
@@ -92,16 +109,17 @@ func loadFromReaderLocked(r io.Reader) error {
 
 	records, err := cr.ReadAll()
 	if err != nil {
-		return fmt.Errorf("ipproto: read protocols csv: %w", err)
+		return nil, fmt.Errorf("ipproto: read protocols csv: %w", err)
 	}
 	if len(records) == 0 {
-		return fmt.Errorf("ipproto: protocols csv is empty")
+		return nil, fmt.Errorf("ipproto: protocols csv is empty")
 	}
 
-	entries = nil
-	byNumber = make(map[int]*Entry)
-	byKeyword = make(map[string]*Entry)
-	byProtocolName = make(map[string]*Entry)
+	t := &table{
+		byNumber:       make(map[int]*Entry),
+		byKeyword:      make(map[string]*Entry),
+		byProtocolName: make(map[string]*Entry),
+	}
 
 	// header: Decimal,Keyword,Protocol,IPv6 Extension Header,Reference
 	startIdx := 1
@@ -141,33 +159,34 @@ func loadFromReaderLocked(r io.Reader) error {
 			Reference:    ref,
 		}
 
-		entries = append(entries, e)
-		idx := len(entries) - 1
-		entryPtr := &entries[idx]
+		t.entries = append(t.entries, e)
+		entryPtr := &t.entries[len(t.entries)-1]
 
 		// Fill byNumber for every value in the range
 		for n := start; n <= end; n++ {
-			byNumber[n] = entryPtr
+			t.byNumber[n] = entryPtr
 		}
 
 		// byKeyword (short name), case-insensitive; we store upper-case
 		if keyword != "" {
 			k := strings.ToUpper(keyword)
-			if _, exists := byKeyword[k]; !exists {
-				byKeyword[k] = entryPtr
+			if _, exists := t.byKeyword[k]; !exists {
+				t.byKeyword[k] = entryPtr
 			}
 		}
 
 		// byProtocolName (long name), normalized
 		if proto != "" {
 			p := normalizeProtoName(proto)
-			if _, exists := byProtocolName[p]; !exists {
-				byProtocolName[p] = entryPtr
+			if _, exists := t.byProtocolName[p]; !exists {
+				t.byProtocolName[p] = entryPtr
 			}
 		}
 	}
 
-	return nil
+	t.byAlias = buildAliasIndex(t)
+
+	return t, nil
 }
 
 // parseDecimalField parses the "Decimal" column, which may be:
@@ -225,12 +244,11 @@ func LookupByNumber(n int) (*Entry, bool) {
 		return nil, false
 	}
 
-	mu.RLock()
-	defer mu.RUnlock()
-	if byNumber == nil {
+	t := current.Load()
+	if t == nil {
 		return nil, false
 	}
-	e, ok := byNumber[n]
+	e, ok := t.byNumber[n]
 	return e, ok
 }
 
@@ -246,9 +264,8 @@ func LookupDecimal(name string) (int, bool) {
 		return 0, false
 	}
 
-	mu.RLock()
-	defer mu.RUnlock()
-	if byKeyword == nil && byProtocolName == nil {
+	t := current.Load()
+	if t == nil {
 		return 0, false
 	}
 
@@ -258,12 +275,12 @@ func LookupDecimal(name string) (int, bool) {
 	}
 
 	// Try Keyword (short name) first
-	if e, ok := byKeyword[strings.ToUpper(name)]; ok {
+	if e, ok := t.byKeyword[strings.ToUpper(name)]; ok {
 		return e.DecimalStart, true
 	}
 
 	// Then try Protocol (long name)
-	if e, ok := byProtocolName[normalizeProtoName(name)]; ok {
+	if e, ok := t.byProtocolName[normalizeProtoName(name)]; ok {
 		return e.DecimalStart, true
 	}
 
@@ -289,5 +306,3 @@ func LookupProtocolName(n int) (string, bool) {
 	}
 	return e.Protocol, true
 }
-
-
@@ -0,0 +1,31 @@
+package ipproto
+
+import "testing"
+
+func BenchmarkLookupByNumber(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		LookupByNumber(6)
+	}
+}
+
+func BenchmarkLookupByNumberParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			LookupByNumber(6)
+		}
+	})
+}
+
+func BenchmarkLookupDecimal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		LookupDecimal("TCP")
+	}
+}
+
+func BenchmarkLookupDecimalParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			LookupDecimal("TCP")
+		}
+	})
+}
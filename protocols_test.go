@@ -0,0 +1,30 @@
+package ipproto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFromFilePersistsAcrossLookups(t *testing.T) {
+	csv := "Decimal,Keyword,Protocol,IPv6 Extension Header,Reference\n250,TESTPROTO,Test Protocol,,\n"
+	path := filepath.Join(t.TempDir(), "custom.csv")
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	// A Lookup* call used to re-trigger the lazy embedded-CSV bootstrap
+	// here, silently reverting the custom table LoadFromFile just installed.
+	if _, ok := LookupByNumber(6); ok {
+		t.Fatal("LookupByNumber(6) found an embedded-CSV entry after LoadFromFile; custom table was reverted")
+	}
+
+	e, ok := LookupByNumber(250)
+	if !ok || e.Keyword != "TESTPROTO" {
+		t.Fatalf("LookupByNumber(250) = %+v, %v; want the TESTPROTO entry from custom.csv", e, ok)
+	}
+}
@@ -0,0 +1,181 @@
+package ipproto
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ianaCSVURL is IANA's canonical protocol-numbers-1.csv endpoint.
+const ianaCSVURL = "https://www.iana.org/assignments/protocol-numbers/protocol-numbers-1.csv"
+
+// RefreshOptions configures a single call to RefreshFromIANA.
+type RefreshOptions struct {
+	// URL overrides the IANA CSV endpoint. Defaults to ianaCSVURL.
+	URL string
+	// Client is the http.Client used for the fetch. Defaults to
+	// http.DefaultClient.
+	Client *http.Client
+	// CachePath, if set, is where the fetched CSV is written on success. If
+	// the request fails or IANA answers with any status other than 200 OK
+	// or 304 Not Modified, RefreshFromIANA falls back to loading this path
+	// instead.
+	CachePath string
+}
+
+var refreshState struct {
+	mu       sync.Mutex
+	etag     string
+	modified string
+}
+
+// RefreshFromIANA fetches the latest protocol-numbers-1.csv from IANA over
+// HTTPS and, if it parses cleanly, atomically swaps it in behind mu without
+// disturbing in-flight readers. It sends If-None-Match/If-Modified-Since
+// headers from the previous successful fetch, so a 304 response is a no-op.
+// If the request fails or the status is neither 200 nor 304 and
+// opts.CachePath is set, the cached copy is loaded instead; otherwise the
+// currently loaded table is left as-is and an error is returned.
+//
+// The table a successful refresh publishes sticks: it is not reverted by a
+// Lookup* call made afterward, so a long-running daemon that both refreshes
+// and looks up protocols stays on the freshly fetched data until the next
+// refresh.
+func RefreshFromIANA(ctx context.Context, opts RefreshOptions) error {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	url := opts.URL
+	if url == "" {
+		url = ianaCSVURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("ipproto: build IANA request: %w", err)
+	}
+
+	refreshState.mu.Lock()
+	if refreshState.etag != "" {
+		req.Header.Set("If-None-Match", refreshState.etag)
+	}
+	if refreshState.modified != "" {
+		req.Header.Set("If-Modified-Since", refreshState.modified)
+	}
+	refreshState.mu.Unlock()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if opts.CachePath != "" {
+			return LoadFromFile(opts.CachePath)
+		}
+		return fmt.Errorf("ipproto: fetch IANA csv: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		if opts.CachePath != "" {
+			return LoadFromFile(opts.CachePath)
+		}
+		return fmt.Errorf("ipproto: fetch IANA csv: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("ipproto: read IANA csv: %w", err)
+	}
+
+	if err := LoadFromReader(bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("ipproto: parse IANA csv: %w", err)
+	}
+
+	refreshState.mu.Lock()
+	refreshState.etag = resp.Header.Get("ETag")
+	refreshState.modified = resp.Header.Get("Last-Modified")
+	refreshState.mu.Unlock()
+
+	if opts.CachePath != "" {
+		if err := os.WriteFile(opts.CachePath, body, 0o644); err != nil {
+			return fmt.Errorf("ipproto: write cache %s: %w", opts.CachePath, err)
+		}
+	}
+
+	return nil
+}
+
+// RefreshPolicy configures the background refresh goroutine started by
+// SetRefreshPolicy.
+type RefreshPolicy struct {
+	// Options is passed to each RefreshFromIANA call.
+	Options RefreshOptions
+	// Interval is the base time between refreshes. A zero or negative
+	// Interval stops any running background refresh without starting a new
+	// one.
+	Interval time.Duration
+	// Jitter, if positive, adds a random duration in [0, Jitter) to each
+	// Interval so that many processes refreshing on the same schedule don't
+	// all hit IANA at once.
+	Jitter time.Duration
+}
+
+var (
+	refreshMu     sync.Mutex
+	refreshCancel context.CancelFunc
+)
+
+// SetRefreshPolicy stops any previously running background refresh started
+// by SetRefreshPolicy and, if policy.Interval > 0, starts a new goroutine
+// that calls RefreshFromIANA on a jittered interval until ctx is canceled or
+// SetRefreshPolicy is called again. A failed refresh is logged nowhere; per
+// RefreshFromIANA's failure behavior it loads Options.CachePath if set and
+// otherwise leaves the current table in place until the next tick. The
+// embedded CSV is not reloaded.
+func SetRefreshPolicy(ctx context.Context, policy RefreshPolicy) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	if refreshCancel != nil {
+		refreshCancel()
+		refreshCancel = nil
+	}
+
+	if policy.Interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	refreshCancel = cancel
+
+	go func() {
+		for {
+			d := policy.Interval
+			if policy.Jitter > 0 {
+				d += time.Duration(rand.Int63n(int64(policy.Jitter)))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(d):
+			}
+
+			_ = RefreshFromIANA(ctx, policy.Options)
+		}
+	}()
+}
+
+// StopRefresh stops the background refresh goroutine started by
+// SetRefreshPolicy, if any is running.
+func StopRefresh() {
+	SetRefreshPolicy(context.Background(), RefreshPolicy{})
+}
@@ -0,0 +1,84 @@
+package ipproto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+const refreshTestCSV = `Decimal,Keyword,Protocol,IPv6 Extension Header,Reference
+6,TCP,Transmission Control,,
+17,UDP,User Datagram,,
+`
+
+func TestRefreshFromIANA(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(refreshTestCSV))
+	}))
+	defer srv.Close()
+
+	if err := RefreshFromIANA(context.Background(), RefreshOptions{URL: srv.URL}); err != nil {
+		t.Fatalf("RefreshFromIANA: %v", err)
+	}
+
+	if n, ok := LookupDecimal("UDP"); !ok || n != 17 {
+		t.Fatalf("LookupDecimal(%q) = %d, %v; want 17, true", "UDP", n, ok)
+	}
+
+	// A Lookup* call must not revert the refreshed table back to embedded:
+	// protocol 1 is in the embedded CSV but not in refreshTestCSV.
+	if _, ok := LookupByNumber(1); ok {
+		t.Fatal("LookupByNumber(1) found an embedded-CSV entry after refresh; refreshed table was reverted")
+	}
+	if _, ok := LookupByNumber(6); !ok {
+		t.Fatal("LookupByNumber(6) not found after refresh")
+	}
+
+	if requests != 1 {
+		t.Fatalf("server saw %d requests, want 1", requests)
+	}
+}
+
+func TestRefreshFromIANANotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(refreshTestCSV))
+	}))
+	defer srv.Close()
+
+	if err := RefreshFromIANA(context.Background(), RefreshOptions{URL: srv.URL}); err != nil {
+		t.Fatalf("first RefreshFromIANA: %v", err)
+	}
+	if err := RefreshFromIANA(context.Background(), RefreshOptions{URL: srv.URL}); err != nil {
+		t.Fatalf("conditional RefreshFromIANA: %v", err)
+	}
+}
+
+func TestRefreshFromIANAFetchFailureFallsBackToCache(t *testing.T) {
+	cachePath := t.TempDir() + "/cache.csv"
+	if err := os.WriteFile(cachePath, []byte(refreshTestCSV), 0o644); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := RefreshFromIANA(context.Background(), RefreshOptions{URL: srv.URL, CachePath: cachePath}); err != nil {
+		t.Fatalf("RefreshFromIANA with failing fetch: %v", err)
+	}
+
+	if n, ok := LookupDecimal("UDP"); !ok || n != 17 {
+		t.Fatalf("LookupDecimal(%q) = %d, %v; want 17, true (from cache)", "UDP", n, ok)
+	}
+}